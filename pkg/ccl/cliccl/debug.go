@@ -46,6 +46,7 @@ const (
 
 var encryptionStatusOpts struct {
 	activeStoreIDOnly bool
+	keysOnly          bool
 }
 
 func init() {
@@ -57,6 +58,7 @@ Shows encryption status of the store located in 'directory'.
 Encryption keys must be specified in the '--enterprise-encryption' flag.
 
 Displays all store and data keys as well as files encrypted with each.
+Specifying --keys-only omits the files and only displays the keys.
 Specifying --active-store-key-id-only prints the key ID of the active store key
 and exits.
 `,
@@ -128,6 +130,8 @@ mode in the current environment.
 	// And other flags.
 	f.BoolVar(&encryptionStatusOpts.activeStoreIDOnly, "active-store-key-id-only", false,
 		"print active store key ID and exit")
+	f.BoolVar(&encryptionStatusOpts.keysOnly, "keys-only", false,
+		"print store and data keys without the files encrypted with each")
 	// For the encryption-decrypt command.
 	f = encryptionDecryptCmd.Flags()
 	cliflagcfg.VarFlag(f, &encryptionSpecs, cliflagsccl.EnterpriseEncryption)
@@ -187,6 +191,7 @@ type PrettyDataKey struct {
 	ID      string
 	Active  bool `json:",omitempty"`
 	Exposed bool `json:",omitempty"`
+	Type    string
 	Created JSONTime
 	Files   []string `json:",omitempty"`
 }
@@ -233,10 +238,15 @@ func runEncryptionStatus(cmd *cobra.Command, args []string) error {
 	}
 
 	if encryptionStatusOpts.activeStoreIDOnly {
-		fmt.Println(keyRegistry.ActiveStoreKeyId)
+		fmt.Fprintln(cmd.OutOrStdout(), keyRegistry.ActiveStoreKeyId)
 		return nil
 	}
 
+	if encryptionStatusOpts.keysOnly {
+		// Drop the files so that only the keys are displayed.
+		fileRegistry.Files = nil
+	}
+
 	// Build a map of 'key ID' -> list of files
 	fileKeyMap := make(map[string][]string)
 
@@ -246,7 +256,7 @@ func runEncryptionStatus(cmd *cobra.Command, args []string) error {
 		if entry.EnvType != enginepb.EnvType_Plaintext && len(entry.EncryptionSettings) > 0 {
 			var setting enginepbccl.EncryptionSettings
 			if err := protoutil.Unmarshal(entry.EncryptionSettings, &setting); err != nil {
-				fmt.Fprintf(os.Stderr, "could not unmarshal encryption settings for file %s: %v", name, err)
+				fmt.Fprintf(cmd.ErrOrStderr(), "could not unmarshal encryption settings for file %s: %v", name, err)
 				continue
 			}
 			keyID = setting.KeyId
@@ -301,6 +311,7 @@ func runEncryptionStatus(cmd *cobra.Command, args []string) error {
 					ID:      c.KeyId,
 					Active:  (c.KeyId == keyRegistry.ActiveDataKeyId),
 					Exposed: c.WasExposed,
+					Type:    c.EncryptionType.String(),
 					Created: JSONTime(timeutil.Unix(c.CreationTime, 0)),
 				}
 				files, ok := fileKeyMap[c.KeyId]
@@ -320,13 +331,13 @@ func runEncryptionStatus(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
-	fmt.Printf("%s\n", j)
+	fmt.Fprintf(cmd.OutOrStdout(), "%s\n", j)
 
 	if len(fileKeyMap) > 0 {
-		fmt.Fprintf(os.Stderr, "WARNING: could not find key info for some files: %+v\n", fileKeyMap)
+		fmt.Fprintf(cmd.ErrOrStderr(), "WARNING: could not find key info for some files: %+v\n", fileKeyMap)
 	}
 	if len(childKeyMap) > 0 {
-		fmt.Fprintf(os.Stderr, "WARNING: could not find parent key info for some data keys: %+v\n", childKeyMap)
+		fmt.Fprintf(cmd.ErrOrStderr(), "WARNING: could not find parent key info for some data keys: %+v\n", childKeyMap)
 	}
 
 	return nil
@@ -14,11 +14,12 @@ import (
 	"sort"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/ccl/baseccl"
-	// The following import is required for the hook that populates
+	// The following import is also required for the hook that populates
 	// NewEncryptedEnvFunc in `pkg/storage`.
-	_ "github.com/cockroachdb/cockroach/pkg/ccl/storageccl/engineccl"
+	"github.com/cockroachdb/cockroach/pkg/ccl/storageccl/engineccl"
 	"github.com/cockroachdb/cockroach/pkg/cli"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/storage"
@@ -28,6 +29,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/randutil"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/datadriven"
 	"github.com/cockroachdb/pebble/vfs"
 	"github.com/spf13/cobra"
@@ -163,6 +165,78 @@ func TestList(t *testing.T) {
 	})
 }
 
+func TestEncryptionStatus(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	// Pin the random generator to use a fixed seed, as in TestList, so that the
+	// generated key IDs are deterministic. Also pin the clock used to stamp key
+	// creation times.
+	reset := envutil.TestSetEnv(t, "COCKROACH_RANDOM_SEED", "1665612120123601000")
+	defer reset()
+	randBefore := rand.Reader
+	randOverride, _ := randutil.NewPseudoRand()
+	rand.Reader = randOverride
+	defer func() { rand.Reader = randBefore }()
+	now := timeutil.Unix(1665612120, 0)
+	defer engineccl.TestingSetKeyManagerTimeNow(func() time.Time { return now })()
+
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	// Generate a new encryption key to use.
+	keyPath := filepath.Join(dir, "aes.key")
+	err := genEncryptionKeyCmd.RunE(nil, []string{keyPath})
+	require.NoError(t, err)
+
+	openStore := func(encSpecStr string) {
+		encSpec, err := baseccl.NewStoreEncryptionSpec(encSpecStr)
+		require.NoError(t, err)
+		encOpts, err := encSpec.ToEncryptionOptions()
+		require.NoError(t, err)
+		env, err := fs.InitEnv(ctx, vfs.Default, dir, fs.EnvConfig{EncryptionOptions: encOpts}, nil /* statsCollector */)
+		require.NoError(t, err)
+		p, err := storage.Open(ctx, env, cluster.MakeClusterSettings())
+		require.NoError(t, err)
+		p.Close()
+	}
+
+	// Spin up a new encrypted store, then an hour later rotate the store key to
+	// plaintext. The registry then holds an AES and a plaintext store key, each
+	// with a data key, and the AES data key is marked as exposed.
+	openStore(fmt.Sprintf("path=%s,key=%s,old-key=plain", dir, keyPath))
+	now = now.Add(time.Hour)
+	encSpecStr := fmt.Sprintf("path=%s,key=plain,old-key=%s", dir, keyPath)
+	openStore(encSpecStr)
+
+	cmd := getTool(cli.DebugCmd, []string{"debug", "encryption-status"})
+	require.NotNil(t, cmd)
+	require.NoError(t, cmd.Flags().Set("enterprise-encryption", encSpecStr))
+	require.Error(t, cmd.ValidateArgs(nil))
+	require.Error(t, cmd.ValidateArgs([]string{dir, dir}))
+
+	datadriven.RunTest(t, datapathutils.TestDataPath(t, "ear-status"), func(t *testing.T, d *datadriven.TestData) string {
+		if d.Cmd != "status" {
+			d.Fatalf(t, "invalid command %q", d.Cmd)
+		}
+		defer func() {
+			encryptionStatusOpts.activeStoreIDOnly = false
+			encryptionStatusOpts.keysOnly = false
+		}()
+		args := []string{dir}
+		for _, arg := range d.CmdArgs {
+			args = append(args, "--"+arg.Key)
+		}
+		require.NoError(t, cmd.ParseFlags(args))
+		require.NoError(t, cmd.ValidateArgs(cmd.Flags().Args()))
+		var b bytes.Buffer
+		cmd.SetOut(&b)
+		cmd.SetErr(&b)
+		require.NoError(t, cmd.RunE(cmd, cmd.Flags().Args()))
+		return strings.ReplaceAll(b.String(), dir, "<dir>")
+	})
+}
+
 // getTool traverses the given cobra.Command recursively, searching for a tool
 // matching the given command.
 func getTool(cmd *cobra.Command, want []string) *cobra.Command {
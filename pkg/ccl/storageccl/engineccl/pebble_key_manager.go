@@ -76,6 +76,15 @@ var _ PebbleKeyManager = &DataKeyManager{}
 // Overridden for testing.
 var kmTimeNow = time.Now
 
+// TestingSetKeyManagerTimeNow overrides the clock used to stamp the creation
+// time of store and data keys. It returns a function that restores the
+// previous clock.
+func TestingSetKeyManagerTimeNow(now func() time.Time) func() {
+	prev := kmTimeNow
+	kmTimeNow = now
+	return func() { kmTimeNow = prev }
+}
+
 // StoreKeyManager manages the user-provided keys. Implements PebbleKeyManager.
 type StoreKeyManager struct {
 	// Initialize the following before calling Load().